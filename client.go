@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxInFlight is the number of queued requests dispatched
+// concurrently when ClientConfig.MaxInFlight is left at zero.
+const defaultMaxInFlight = 4
+
+// retryLogHook is installed as a retryablehttp.Client.RequestLogHook so that
+// every retry attempt - regardless of which Client issued it - is counted.
+func retryLogHook(_ retryablehttp.Logger, _ *http.Request, retryNumber int) {
+	if retryNumber > 0 {
+		fetchRetryTotal.Inc()
+	}
+}
+
+// ClientConfig configures a Client. Endpoint is the base URL requests are
+// resolved against; HTTPClient and Logger default to a plain http.Client and
+// StdLogger respectively when left nil.
+type ClientConfig struct {
+	Endpoint     string
+	HTTPClient   *http.Client
+	Logger       Logger
+	RetryMax     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// MaxInFlight bounds how many queued requests the Client dispatches
+	// concurrently, so the priority queue's ordering is actually observed
+	// under contention instead of every popped item immediately running.
+	// Defaults to 4 when zero.
+	MaxInFlight int
+}
+
+// Client wraps a retryablehttp.Client with a rate limiter, and schedules all
+// outgoing requests through a priority queue so that a single shared
+// connection pool and rate limiter can serve many concurrent callers without
+// exceeding upstream quotas. Retry semantics - idempotency checks,
+// connection-reset handling, Retry-After parsing - are delegated to
+// retryablehttp rather than hand-rolled.
+type Client struct {
+	endpoint   string
+	httpClient *retryablehttp.Client
+	limiter    *rate.Limiter
+	logger     Logger
+
+	queueMu  sync.Mutex
+	queue    requestQueue
+	queueCh  chan struct{}
+	nextSeq  int
+	inFlight chan struct{}
+
+	Posts    *PostsService
+	Users    *UsersService
+	Comments *CommentsService
+}
+
+// NewClient builds a Client from cfg and an explicit rate limiter, and
+// starts its background queue processor.
+func NewClient(cfg ClientConfig, limiter *rate.Limiter) *Client {
+	if cfg.Logger == nil {
+		cfg.Logger = NewStdLogger()
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	rc := retryablehttp.NewClient()
+	rc.HTTPClient = cfg.HTTPClient
+	rc.RetryMax = cfg.RetryMax
+	rc.RetryWaitMin = cfg.RetryWaitMin
+	rc.RetryWaitMax = cfg.RetryWaitMax
+	rc.Logger = leveledLogger{cfg.Logger}
+	rc.RequestLogHook = retryLogHook
+	// Hand back the last response once retries are exhausted instead of
+	// retryablehttp's default of discarding it and returning an error.
+	rc.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	// Do only waits on the limiter before the first attempt; without this,
+	// retryablehttp's internal retry loop would fire every subsequent
+	// attempt completely unthrottled.
+	rc.PrepareRetry = func(req *http.Request) error {
+		return limiter.Wait(req.Context())
+	}
+
+	maxInFlight := cfg.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	c := &Client{
+		endpoint:   cfg.Endpoint,
+		httpClient: rc,
+		limiter:    limiter,
+		logger:     cfg.Logger,
+		queueCh:    make(chan struct{}, 1),
+		inFlight:   make(chan struct{}, maxInFlight),
+	}
+	c.Posts = &PostsService{client: c}
+	c.Users = &UsersService{client: c}
+	c.Comments = &CommentsService{client: c}
+
+	go c.queueProcessor()
+	return c
+}
+
+// NewClientFromEnv builds a Client configured from the API_BASE_URL,
+// API_RPS, API_BURST, RETRY_MIN_DELAY, RETRY_MAX_DELAY, MAX_RETRIES and
+// MAX_INFLIGHT environment variables, falling back to sensible defaults when unset.
+func NewClientFromEnv() *Client {
+	endpoint := os.Getenv("API_BASE_URL")
+	if endpoint == "" {
+		endpoint = "https://jsonplaceholder.typicode.com"
+	}
+	rps := envFloat("API_RPS", 5)
+	burst := envInt("API_BURST", 5)
+
+	return NewClient(ClientConfig{
+		Endpoint:     endpoint,
+		Logger:       NewStdLogger(),
+		RetryMax:     envInt("MAX_RETRIES", 3),
+		RetryWaitMin: envDuration("RETRY_MIN_DELAY", 200*time.Millisecond),
+		RetryWaitMax: envDuration("RETRY_MAX_DELAY", 5*time.Second),
+		MaxInFlight:  envInt("MAX_INFLIGHT", defaultMaxInFlight),
+	}, rate.NewLimiter(rate.Limit(rps), burst))
+}
+
+func envFloat(key string, def float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+func envInt(key string, def int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return def
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
+// Do waits on the rate limiter and then executes req through the
+// retryablehttp client, which transparently retries on connection resets,
+// 429s and 5xx responses honoring Retry-After. The limiter is also consulted
+// before every retry (via rc.PrepareRetry, set in NewClient), not just the
+// first attempt, so a single Send call can never exceed the configured rate
+// regardless of how many retries it takes. The final response is returned
+// as-is, even if non-2xx, so callers can inspect its status and body (e.g.
+// Send decoding an APIError); only transport-level failures are returned as
+// an error.
+func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter wait: %w", err)
+	}
+
+	rreq, err := retryablehttp.FromRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing retryable request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(rreq)
+	fetchDurationSeconds.Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		fetchRequestsTotal.WithLabelValues("error").Inc()
+		return nil, fmt.Errorf("error fetching data: %w", err)
+	}
+	fetchRequestsTotal.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+
+	return resp, nil
+}
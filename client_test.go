@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestNewClientDefaultsLoggerAndHTTPClient(t *testing.T) {
+	c := NewClient(ClientConfig{Endpoint: "https://example.com"}, nil)
+
+	if c.logger == nil {
+		t.Error("expected NewClient to default Logger to a StdLogger")
+	}
+	if c.httpClient.HTTPClient == nil {
+		t.Error("expected NewClient to default HTTPClient")
+	}
+}
+
+// TestDoWaitsOnLimiterBetweenRetries guards against retryablehttp's internal
+// retry loop firing unthrottled: with a limiter that only allows one request
+// per 100ms and an always-429 server, Do must block on the second
+// attempt rather than let all retries through immediately.
+func TestDoWaitsOnLimiterBetweenRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	limiter := rate.NewLimiter(rate.Every(100*time.Millisecond), 1)
+	c := NewClient(ClientConfig{
+		Endpoint:     server.URL,
+		RetryMax:     2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	}, limiter)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+
+	start := time.Now()
+	// Do hands back the final 429 response once retries are exhausted; what
+	// this test cares about is how long it took.
+	c.Do(context.Background(), req)
+	elapsed := time.Since(start)
+
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Fatalf("expected 3 upstream requests (1 + 2 retries), got %d", got)
+	}
+	// Two retries each gated on a limiter refilling every 100ms should take
+	// at least ~200ms; an unthrottled retry loop finishes in well under 10ms.
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("retries completed in %s, expected them to be throttled by the limiter", elapsed)
+	}
+}
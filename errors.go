@@ -0,0 +1,19 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// APIError is returned when the upstream API responds with a non-2xx status
+// and a JSON error body. Callers can use errors.As to recover one from an
+// error returned by Send for programmatic handling.
+type APIError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.Code, e.Message)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+// Logger is the structured logging interface used throughout the client.
+// Implementations can wrap zap, logrus, slog, or anything else the caller
+// prefers; StdLogger adapts the standard library logger so CloudWatch gets
+// leveled lines without any extra dependency.
+type Logger interface {
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+	Warn(args ...interface{})
+	Warnf(format string, args ...interface{})
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// StdLogger adapts the standard library's log.Logger to the Logger
+// interface, prefixing each line with its level so CloudWatch log groups
+// can be filtered by severity.
+type StdLogger struct {
+	*log.Logger
+}
+
+// NewStdLogger builds a StdLogger writing to stderr, the stream CloudWatch
+// Logs captures for a Lambda function.
+func NewStdLogger() *StdLogger {
+	return &StdLogger{log.New(os.Stderr, "", log.LstdFlags)}
+}
+
+func (l *StdLogger) Debug(args ...interface{}) { l.Output(2, "DEBUG "+fmt.Sprint(args...)) }
+func (l *StdLogger) Debugf(format string, args ...interface{}) {
+	l.Output(2, "DEBUG "+fmt.Sprintf(format, args...))
+}
+func (l *StdLogger) Info(args ...interface{}) { l.Output(2, "INFO "+fmt.Sprint(args...)) }
+func (l *StdLogger) Infof(format string, args ...interface{}) {
+	l.Output(2, "INFO "+fmt.Sprintf(format, args...))
+}
+func (l *StdLogger) Warn(args ...interface{}) { l.Output(2, "WARN "+fmt.Sprint(args...)) }
+func (l *StdLogger) Warnf(format string, args ...interface{}) {
+	l.Output(2, "WARN "+fmt.Sprintf(format, args...))
+}
+func (l *StdLogger) Error(args ...interface{}) { l.Output(2, "ERROR "+fmt.Sprint(args...)) }
+func (l *StdLogger) Errorf(format string, args ...interface{}) {
+	l.Output(2, "ERROR "+fmt.Sprintf(format, args...))
+}
+
+// leveledLogger adapts a Logger to retryablehttp's LeveledLogger interface
+// so the library's internal retry/backoff logging flows through it too.
+type leveledLogger struct {
+	Logger
+}
+
+func (l leveledLogger) Error(msg string, kv ...interface{}) { l.Logger.Errorf("%s %v", msg, kv) }
+func (l leveledLogger) Info(msg string, kv ...interface{})  { l.Logger.Infof("%s %v", msg, kv) }
+func (l leveledLogger) Debug(msg string, kv ...interface{}) { l.Logger.Debugf("%s %v", msg, kv) }
+func (l leveledLogger) Warn(msg string, kv ...interface{})  { l.Logger.Warnf("%s %v", msg, kv) }
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestStdLoggerPrefixesLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := &StdLogger{log.New(&buf, "", 0)}
+
+	l.Infof("fetched %d posts", 10)
+
+	if got := buf.String(); !strings.HasPrefix(got, "INFO ") || !strings.Contains(got, "fetched 10 posts") {
+		t.Errorf("unexpected log output: %q", got)
+	}
+}
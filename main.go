@@ -4,11 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
-	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
@@ -29,108 +27,111 @@ type Response struct {
 	Body       string            `json:"body"`
 }
 
-// fetchPosts retrieves posts from the JSONPlaceholder API
-func fetchPosts(ctx context.Context) ([]Post, error) {
-	// Get API URL from environment variable or use default
-	apiURL := os.Getenv("API_URL")
-	if apiURL == "" {
-		apiURL = "https://jsonplaceholder.typicode.com/posts"
-	}
-
-	log.Printf("Fetching data from: %s", apiURL)
-
-	// Create an HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// Create a new request with context for cancellation support
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
-	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		return nil, fmt.Errorf("error creating request: %v", err)
-	}
-
-	// Execute the request
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("Error fetching data: %v", err)
-		return nil, fmt.Errorf("error fetching data: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("API returned non-OK status: %d", resp.StatusCode)
-		return nil, fmt.Errorf("API returned status code %d", resp.StatusCode)
-	}
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("Error reading response body: %v", err)
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-
-	// Unmarshal JSON response
-	var posts []Post
-	err = json.Unmarshal(body, &posts)
-	if err != nil {
-		log.Printf("Error parsing JSON: %v", err)
-		return nil, fmt.Errorf("error parsing JSON: %v", err)
-	}
-
-	return posts, nil
+// fanOutResult holds the aggregated data from a concurrent fetch of every
+// resource HandleRequest composes into its response.
+type fanOutResult struct {
+	Posts    []Post
+	Users    []User
+	Comments []Comment
 }
 
-func fetchPostsWithRetry(ctx context.Context, maxRetries int) ([]Post, error) {
-	var lastErr error
-	for i := 0; i < maxRetries; i++ {
-		posts, err := fetchPosts(ctx)
-		if err == nil {
-			return posts, nil
+// fetchAll fans out List calls to every service concurrently against the
+// shared rate-limited client, returning the first error encountered if any.
+func fetchAll(ctx context.Context, client *Client) (*fanOutResult, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		result   fanOutResult
+		firstErr error
+	)
+
+	fetch := func(fn func() error) {
+		defer wg.Done()
+		if err := fn(); err != nil {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
 		}
+	}
 
-		lastErr = err
-		log.Printf("Retry %d/%d failed: %v", i+1, maxRetries, err)
-
-		// Exponential backoff
-		sleepTime := time.Duration((1<<i)*100) * time.Millisecond
-		select {
-		case <-time.After(sleepTime):
-			continue
-		case <-ctx.Done():
-			return nil, fmt.Errorf("context cancelled during retry: %v", ctx.Err())
+	wg.Add(3)
+	go fetch(func() error {
+		msg, err := client.Posts.List(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.Posts = msg.Data
+		mu.Unlock()
+		return nil
+	})
+	go fetch(func() error {
+		msg, err := client.Users.List(ctx)
+		if err != nil {
+			return err
 		}
+		mu.Lock()
+		result.Users = msg.Data
+		mu.Unlock()
+		return nil
+	})
+	go fetch(func() error {
+		msg, err := client.Comments.List(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.Comments = msg.Data
+		mu.Unlock()
+		return nil
+	})
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
 	}
+	return &result, nil
+}
 
-	return nil, fmt.Errorf("all %d retries failed, last error: %v", maxRetries, lastErr)
+// lambdaClient is built once and reused across invocations of a warm Lambda
+// container, so its rate limiter, retry state and connection pool actually
+// persist between calls instead of resetting every invocation.
+var (
+	lambdaClient     *Client
+	lambdaClientOnce sync.Once
+)
+
+func getLambdaClient() *Client {
+	lambdaClientOnce.Do(func() {
+		lambdaClient = NewClientFromEnv()
+	})
+	return lambdaClient
 }
 
 // HandleRequest is our Lambda function handler
 func HandleRequest(ctx context.Context) (Response, error) {
 	log.Println("Lambda execution started")
 
-	// Get max retries from environment variable or use default
-	maxRetries := 3
-	if maxRetriesStr := os.Getenv("MAX_RETRIES"); maxRetriesStr != "" {
-		if val, err := strconv.Atoi(maxRetriesStr); err == nil {
-			maxRetries = val
-		}
-	}
+	fetchCtx, cancel := context.WithTimeout(ctx, envDuration("FETCH_TIMEOUT", 8*time.Second))
+	defer cancel()
 
-	// Fetch posts from API
-	posts, err := fetchPostsWithRetry(ctx, maxRetries) // Try up to 3 times
+	client := getLambdaClient()
 
+	// Fan out to every resource concurrently against the shared rate-limited client
+	results, err := fetchAll(fetchCtx, client)
 	if err != nil {
-		log.Printf("Failed to fetch posts: %v", err)
+		log.Printf("Failed to fetch endpoints: %v", err)
 		return Response{
 			StatusCode: 500,
 			Headers:    map[string]string{"Content-Type": "application/json"},
-			Body:       fmt.Sprintf(`{"error": "Failed to fetch posts: %v"}`, err),
+			Body:       fmt.Sprintf(`{"error": "Failed to fetch endpoints: %v"}`, err),
 		}, nil
 	}
 
+	posts := results.Posts
+
 	// Log the total number of items
 	totalItems := len(posts)
 	log.Printf("Total items fetched: %d", totalItems)
@@ -153,6 +154,8 @@ func HandleRequest(ctx context.Context) (Response, error) {
 	responseData := map[string]interface{}{
 		"total":          totalItems,
 		"firstItemTitle": firstItemTitle,
+		"users":          len(results.Users),
+		"comments":       len(results.Comments),
 	}
 
 	responseBody, err := json.Marshal(responseData)
@@ -174,6 +177,13 @@ func HandleRequest(ctx context.Context) (Response, error) {
 }
 
 func main() {
-	// Start the Lambda handler
+	// Running as a container on ECS/K8s sets PORT; otherwise start the Lambda handler
+	if port := os.Getenv("PORT"); port != "" {
+		if err := runServer(port); err != nil {
+			log.Fatalf("server error: %v", err)
+		}
+		return
+	}
+
 	lambda.Start(HandleRequest)
 }
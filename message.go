@@ -0,0 +1,9 @@
+package main
+
+// APIMessage is the typed envelope returned by Send[T]. Error is non-nil
+// only when the upstream responded with a structured JSON error body; other
+// failures are returned directly as the error result of Send.
+type APIMessage[T any] struct {
+	Data  T
+	Error *APIError
+}
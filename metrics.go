@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// fetchRequestsTotal counts completed upstream fetches by final status,
+	// using "error" when the request never got a response at all.
+	fetchRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fetch_requests_total",
+		Help: "Total number of upstream fetch requests, by final HTTP status.",
+	}, []string{"status"})
+
+	// fetchRetryTotal counts retry attempts made by the retryablehttp client.
+	fetchRetryTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fetch_retry_total",
+		Help: "Total number of upstream fetch retries.",
+	})
+
+	// fetchDurationSeconds observes the wall-clock time of each upstream
+	// fetch (one Client.Do call), including time spent retrying.
+	fetchDurationSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "fetch_duration_seconds",
+		Help:    "Duration of upstream fetch requests in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// sendResult is the outcome of a queued Send call.
+type sendResult struct {
+	Body       []byte
+	StatusCode int
+	Err        error
+}
+
+// queuedRequest is one entry waiting in a Client's priority queue.
+type queuedRequest struct {
+	ctx      context.Context
+	method   string
+	url      string
+	payload  []byte
+	priority int
+	seq      int // submission order, used as a tiebreaker
+	index    int // maintained by container/heap
+	resultCh chan sendResult
+}
+
+// requestQueue implements container/heap.Interface, ordering by priority
+// (higher priority first) and by submission order among equal priorities.
+type requestQueue []*queuedRequest
+
+func (q requestQueue) Len() int { return len(q) }
+
+func (q requestQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q requestQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+func (q *requestQueue) Push(x any) {
+	item := x.(*queuedRequest)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *requestQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*q = old[:n-1]
+	return item
+}
+
+// Send enqueues a request to be sent through the client's shared HTTP client
+// and rate limiter, ordered by priority (higher runs first). The result is
+// delivered on the returned channel once the request completes or fails.
+func (c *Client) Send(ctx context.Context, method, url string, payload []byte, priority int) <-chan sendResult {
+	resultCh := make(chan sendResult, 1)
+
+	c.queueMu.Lock()
+	c.nextSeq++
+	item := &queuedRequest{
+		ctx:      ctx,
+		method:   method,
+		url:      url,
+		payload:  payload,
+		priority: priority,
+		seq:      c.nextSeq,
+		resultCh: resultCh,
+	}
+	heap.Push(&c.queue, item)
+	c.queueMu.Unlock()
+
+	select {
+	case c.queueCh <- struct{}{}:
+	default:
+	}
+
+	return resultCh
+}
+
+// queueProcessor drains the priority queue, dispatching each request as it
+// is popped. Dispatch is bounded by c.inFlight: popping the next item blocks
+// until a worker slot is free, so under contention the heap's priority order
+// is actually what determines dispatch order, not just goroutine scheduling.
+// It runs for the lifetime of the Client.
+func (c *Client) queueProcessor() {
+	for range c.queueCh {
+		for {
+			c.inFlight <- struct{}{}
+			c.queueMu.Lock()
+			if len(c.queue) == 0 {
+				c.queueMu.Unlock()
+				<-c.inFlight
+				break
+			}
+			item := heap.Pop(&c.queue).(*queuedRequest)
+			c.queueMu.Unlock()
+
+			go func(item *queuedRequest) {
+				defer func() { <-c.inFlight }()
+				c.process(item)
+			}(item)
+		}
+	}
+}
+
+// process executes a single queued request and delivers its result.
+func (c *Client) process(item *queuedRequest) {
+	var body io.Reader
+	if item.payload != nil {
+		body = bytes.NewReader(item.payload)
+	}
+
+	req, err := http.NewRequestWithContext(item.ctx, item.method, item.url, body)
+	if err != nil {
+		item.resultCh <- sendResult{Err: fmt.Errorf("error creating request: %w", err)}
+		return
+	}
+
+	resp, err := c.Do(item.ctx, req)
+	if err != nil {
+		item.resultCh <- sendResult{Err: err}
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		item.resultCh <- sendResult{Err: fmt.Errorf("error reading response body: %w", err)}
+		return
+	}
+
+	item.resultCh <- sendResult{Body: respBody, StatusCode: resp.StatusCode}
+}
+
+// await blocks until ch delivers a result or ctx is done, whichever comes first.
+func await(ctx context.Context, ch <-chan sendResult) (sendResult, error) {
+	select {
+	case res := <-ch:
+		return res, nil
+	case <-ctx.Done():
+		return sendResult{}, ctx.Err()
+	}
+}
+
+// Send performs a typed request against c's configured Endpoint, decoding a
+// 2xx JSON body into T. A non-2xx response is decoded as an APIError when
+// the body is JSON shaped like one; otherwise the raw status is returned as
+// a plain error. It is a free function, not a method, because Go methods
+// cannot take their own type parameters.
+func Send[T any](ctx context.Context, c *Client, method, path string, priority int) (*APIMessage[T], error) {
+	resCh := c.Send(ctx, method, c.endpoint+path, nil, priority)
+	res, err := await(ctx, resCh)
+	if err != nil {
+		return nil, err
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		var apiErr APIError
+		if jsonErr := json.Unmarshal(res.Body, &apiErr); jsonErr == nil {
+			if apiErr.Code == 0 {
+				apiErr.Code = res.StatusCode
+			}
+			return &APIMessage[T]{Error: &apiErr}, &apiErr
+		}
+		return nil, fmt.Errorf("API returned status code %d", res.StatusCode)
+	}
+
+	var data T
+	if err := json.Unmarshal(res.Body, &data); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+
+	return &APIMessage[T]{Data: data}, nil
+}
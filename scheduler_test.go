@@ -0,0 +1,74 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRequestQueueOrdersByPriorityThenSeq(t *testing.T) {
+	q := &requestQueue{}
+	heap.Init(q)
+
+	heap.Push(q, &queuedRequest{priority: 1, seq: 1})
+	heap.Push(q, &queuedRequest{priority: 5, seq: 2})
+	heap.Push(q, &queuedRequest{priority: 5, seq: 3})
+	heap.Push(q, &queuedRequest{priority: 10, seq: 4})
+
+	var order []int
+	for q.Len() > 0 {
+		item := heap.Pop(q).(*queuedRequest)
+		order = append(order, item.seq)
+	}
+
+	want := []int{4, 2, 3, 1}
+	if len(order) != len(want) {
+		t.Fatalf("expected %d items, got %d", len(want), len(order))
+	}
+	for i, seq := range want {
+		if order[i] != seq {
+			t.Errorf("position %d: expected seq %d, got %d", i, seq, order[i])
+		}
+	}
+}
+
+func TestQueueProcessorBoundsConcurrency(t *testing.T) {
+	var (
+		current int32
+		maxSeen int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{Endpoint: server.URL, MaxInFlight: 2}, rate.NewLimiter(rate.Inf, 1))
+
+	const requests = 8
+	channels := make([]<-chan sendResult, requests)
+	for i := range channels {
+		channels[i] = client.Send(context.Background(), http.MethodGet, server.URL, nil, 0)
+	}
+	for _, ch := range channels {
+		<-ch
+	}
+
+	if maxSeen > 2 {
+		t.Errorf("expected at most 2 concurrent requests, saw %d", maxSeen)
+	}
+}
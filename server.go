@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/NYTimes/gziphandler"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// apiResponse is the standard {status, data, errorType, error} envelope used
+// by Prometheus-family APIs, so existing dashboards can consume this server.
+type apiResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+func writeAPIResponse(w http.ResponseWriter, httpStatus int, resp apiResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// withCORS allows the API to be called directly from a browser.
+func withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newServerMux builds the HTTP surface mounted when PORT is set: a small API
+// in the spirit of the Thanos rule API (GET /api/v1/posts), a readiness
+// probe, and Prometheus metrics.
+func newServerMux(client *Client) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v1/posts", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), envDuration("FETCH_TIMEOUT", 8*time.Second))
+		defer cancel()
+
+		msg, err := client.Posts.List(ctx)
+		if err != nil {
+			writeAPIResponse(w, http.StatusBadGateway, apiResponse{
+				Status:    "error",
+				ErrorType: "upstream",
+				Error:     err.Error(),
+			})
+			return
+		}
+		writeAPIResponse(w, http.StatusOK, apiResponse{Status: "success", Data: msg.Data})
+	})
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, http.StatusOK, apiResponse{Status: "success", Data: "ready"})
+	})
+
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+// runServer starts the HTTP server mode activated by the PORT environment
+// variable, so the same binary can run under Lambda or as a container on
+// ECS/K8s.
+func runServer(port string) error {
+	client := NewClientFromEnv()
+	handler := gziphandler.GzipHandler(withCORS(newServerMux(client)))
+
+	log.Printf("Listening on :%s", port)
+	return http.ListenAndServe(":"+port, handler)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthzReportsReady(t *testing.T) {
+	client := NewClientFromEnv()
+	server := httptest.NewServer(newServerMux(client))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// User represents the structure of data from the JSONPlaceholder /users endpoint.
+type User struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// Comment represents the structure of data from the JSONPlaceholder /comments endpoint.
+type Comment struct {
+	PostID int    `json:"postId"`
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Body   string `json:"body"`
+}
+
+// PostsService exposes the /posts resource.
+type PostsService struct {
+	client *Client
+}
+
+// List fetches all posts.
+func (s *PostsService) List(ctx context.Context) (*APIMessage[[]Post], error) {
+	return Send[[]Post](ctx, s.client, http.MethodGet, "/posts", 10)
+}
+
+// Get fetches a single post by id.
+func (s *PostsService) Get(ctx context.Context, id int) (*APIMessage[Post], error) {
+	return Send[Post](ctx, s.client, http.MethodGet, fmt.Sprintf("/posts/%d", id), 10)
+}
+
+// UsersService exposes the /users resource.
+type UsersService struct {
+	client *Client
+}
+
+// List fetches all users.
+func (s *UsersService) List(ctx context.Context) (*APIMessage[[]User], error) {
+	return Send[[]User](ctx, s.client, http.MethodGet, "/users", 5)
+}
+
+// Get fetches a single user by id.
+func (s *UsersService) Get(ctx context.Context, id int) (*APIMessage[User], error) {
+	return Send[User](ctx, s.client, http.MethodGet, fmt.Sprintf("/users/%d", id), 5)
+}
+
+// CommentsService exposes the /comments resource.
+type CommentsService struct {
+	client *Client
+}
+
+// List fetches all comments.
+func (s *CommentsService) List(ctx context.Context) (*APIMessage[[]Comment], error) {
+	return Send[[]Comment](ctx, s.client, http.MethodGet, "/comments", 1)
+}
+
+// Get fetches a single comment by id.
+func (s *CommentsService) Get(ctx context.Context, id int) (*APIMessage[Comment], error) {
+	return Send[Comment](ctx, s.client, http.MethodGet, fmt.Sprintf("/comments/%d", id), 1)
+}
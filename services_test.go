@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestSendDecodesAPIErrorOnNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "post not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{Endpoint: server.URL}, rate.NewLimiter(rate.Inf, 1))
+
+	_, err := client.Posts.Get(context.Background(), 999)
+	if err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected errors.As to find an *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Message != "post not found" {
+		t.Errorf("expected message %q, got %q", "post not found", apiErr.Message)
+	}
+	if apiErr.Code != http.StatusNotFound {
+		t.Errorf("expected code %d, got %d", http.StatusNotFound, apiErr.Code)
+	}
+}
+
+// TestSendReturnsErrorOnNon2xxWithoutJSONBody guards against a non-2xx
+// response ever being treated as successful just because its body isn't
+// APIError-shaped JSON: it must still surface as an error, not get parsed
+// into T as if the request had succeeded.
+func TestSendReturnsErrorOnNon2xxWithoutJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("plain text error, not JSON"))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{Endpoint: server.URL}, rate.NewLimiter(rate.Inf, 1))
+
+	_, err := client.Posts.Get(context.Background(), 1)
+	if err == nil {
+		t.Fatal("expected an error for a 400 response with a non-JSON body")
+	}
+}